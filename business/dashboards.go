@@ -0,0 +1,144 @@
+package business
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/models"
+	"github.com/kiali/kiali/prometheus"
+)
+
+// runtimesAnnotation and dashboardsAnnotation are the pod annotations custom runtime
+// dashboards are resolved from: runtimesAnnotation names a runtime Kiali ships a built-in
+// template for (e.g. "go", "envoy"), dashboardsAnnotation names a MonitoringDashboard CR
+// directly. Both are comma-separated lists.
+const (
+	runtimesAnnotation   = "kiali.io/runtimes"
+	dashboardsAnnotation = "kiali.io/dashboards"
+)
+
+// kubeClientFunc resolves the Kubernetes client GetCustomDashboardRefs uses to look up pod
+// annotations. It is a variable, analogous to metricsProviderFunc in the grpc package, so
+// tests can substitute a stub instead of a real cluster.
+//
+// It falls back to kubernetes.NewClientFromConfig's service-account config rather than the
+// caller's own already-authenticated, RBAC-scoped client, because this package doesn't yet
+// have an accessor onto the client business.Layer (the object getBusiness(r) hands every
+// other handler in this series) already holds internally. Until that accessor exists, at
+// least resolve the client once per GetCustomDashboardRefs call instead of once per pod.
+var kubeClientFunc = kubernetes.NewClientFromConfig
+
+// podAnnotationsFunc resolves a single pod's annotations given an already-resolved client. It
+// is a variable, rather than a direct client call, so that tests can substitute fixed
+// annotations instead of exercising a real cluster. An error (pod not found, ...) is treated
+// by GetCustomDashboardRefs the same as "no annotations known" for that pod.
+var podAnnotationsFunc = func(client kubernetes.ClientInterface, namespace, pod string) (map[string]string, error) {
+	p, err := client.GetPod(namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+	return p.Annotations, nil
+}
+
+// DashboardsService serves Istio and custom runtime dashboards. It is backed by a
+// prometheus.MetricsProvider rather than a concrete *prometheus.Client, so it works the same
+// way regardless of which metrics_backend (Prometheus, Thanos, remote) is configured.
+type DashboardsService struct {
+	prom prometheus.MetricsProvider
+}
+
+// NewDashboardsService creates a DashboardsService backed by the given metrics provider.
+func NewDashboardsService(prom prometheus.MetricsProvider) *DashboardsService {
+	return &DashboardsService{prom: prom}
+}
+
+// GetIstioDashboard builds the Istio dashboard (request volume, duration, size, ...) for the
+// object described by query.
+func (in *DashboardsService) GetIstioDashboard(query prometheus.IstioMetricsQuery) (models.MonitoringDashboard, error) {
+	metrics := in.prom.GetMetrics(&query)
+	return models.MonitoringDashboard{Metrics: metrics}, nil
+}
+
+// GetCustomDashboard serves the named custom runtime dashboard for the given pods.
+// dashboardName must be one of the refs GetCustomDashboardRefs would have returned for those
+// same pods; an unknown name means the pods don't actually expose that dashboard, so it's
+// rejected rather than silently rendered. For a known name, the returned dashboard holds the
+// Istio metrics for query, as GetIstioDashboard does; no MonitoringDashboard CR is read, so
+// the charts that CR defines are not part of the result.
+func (in *DashboardsService) GetCustomDashboard(namespace, dashboardName string, pods models.Pods, query prometheus.IstioMetricsQuery) (models.MonitoringDashboard, error) {
+	if len(pods) == 0 {
+		return models.MonitoringDashboard{}, fmt.Errorf("no pods to resolve custom dashboard %q for", dashboardName)
+	}
+
+	podNames := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		podNames = append(podNames, pod.Name)
+	}
+	if !refsContain(GetCustomDashboardRefs(namespace, "", "", podNames), dashboardName) {
+		return models.MonitoringDashboard{}, fmt.Errorf("dashboard %q is not exposed by any of the given pods", dashboardName)
+	}
+
+	metrics := in.prom.GetMetrics(&query)
+	return models.MonitoringDashboard{Metrics: metrics}, nil
+}
+
+// refsContain reports whether refs already lists dashboardName, used to validate a requested
+// custom dashboard name against what the pods actually expose.
+func refsContain(refs models.MonitoringDashboardsRefs, dashboardName string) bool {
+	for _, ref := range refs {
+		if ref.Template == dashboardName {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCustomDashboardRefs resolves which custom runtime dashboards (MonitoringDashboard CRs)
+// apply to a group of pods sharing the same app/version, by inspecting their
+// "kiali.io/runtimes" and "kiali.io/dashboards" annotations. namespace is the pods' own
+// namespace, used to look the pods up; no MonitoringDashboard CR is fetched. Refs are
+// deduplicated across the group, since pods of the same app/version are expected to run the
+// same runtimes.
+func GetCustomDashboardRefs(namespace, app, version string, pods []string) models.MonitoringDashboardsRefs {
+	seen := make(map[string]bool)
+	refs := models.MonitoringDashboardsRefs{}
+
+	client, err := kubeClientFunc()
+	if err != nil {
+		return refs
+	}
+
+	for _, pod := range pods {
+		annotations, err := podAnnotationsFunc(client, namespace, pod)
+		if err != nil || len(annotations) == 0 {
+			continue
+		}
+		for _, template := range dashboardTemplatesFromAnnotations(annotations) {
+			if seen[template] {
+				continue
+			}
+			seen[template] = true
+			refs = append(refs, models.MonitoringDashboardRef{Template: template, Title: template})
+		}
+	}
+	return refs
+}
+
+// dashboardTemplatesFromAnnotations extracts the comma-separated template names named by a
+// pod's runtimes/dashboards annotations.
+func dashboardTemplatesFromAnnotations(annotations map[string]string) []string {
+	var templates []string
+	for _, annotation := range []string{dashboardsAnnotation, runtimesAnnotation} {
+		raw, ok := annotations[annotation]
+		if !ok {
+			continue
+		}
+		for _, name := range strings.Split(raw, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				templates = append(templates, name)
+			}
+		}
+	}
+	return templates
+}
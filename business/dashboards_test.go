@@ -0,0 +1,75 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestDashboardTemplatesFromAnnotationsSplitsAndTrims(t *testing.T) {
+	templates := dashboardTemplatesFromAnnotations(map[string]string{
+		runtimesAnnotation: "go, envoy",
+	})
+
+	assert.Equal(t, []string{"go", "envoy"}, templates)
+}
+
+func TestDashboardTemplatesFromAnnotationsMergesBothKeys(t *testing.T) {
+	templates := dashboardTemplatesFromAnnotations(map[string]string{
+		dashboardsAnnotation: "custom-cr",
+		runtimesAnnotation:   "go",
+	})
+
+	assert.Equal(t, []string{"custom-cr", "go"}, templates)
+}
+
+// stubKubeClient substitutes kubeClientFunc for the duration of a test, so
+// GetCustomDashboardRefs never reaches for a real cluster.
+func stubKubeClient(t *testing.T) {
+	originalClientFunc := kubeClientFunc
+	t.Cleanup(func() { kubeClientFunc = originalClientFunc })
+	kubeClientFunc = func() (kubernetes.ClientInterface, error) {
+		return nil, nil
+	}
+}
+
+func TestGetCustomDashboardRefsDeduplicatesAcrossPods(t *testing.T) {
+	stubKubeClient(t)
+	original := podAnnotationsFunc
+	defer func() { podAnnotationsFunc = original }()
+	podAnnotationsFunc = func(client kubernetes.ClientInterface, namespace, pod string) (map[string]string, error) {
+		return map[string]string{runtimesAnnotation: "go"}, nil
+	}
+
+	refs := GetCustomDashboardRefs("bookinfo", "reviews", "v1", []string{"reviews-v1-abc", "reviews-v1-def"})
+
+	assert.Len(t, refs, 1)
+	assert.Equal(t, "go", refs[0].Template)
+}
+
+func TestGetCustomDashboardRefsSkipsPodsWithoutAnnotations(t *testing.T) {
+	stubKubeClient(t)
+	original := podAnnotationsFunc
+	defer func() { podAnnotationsFunc = original }()
+	podAnnotationsFunc = func(client kubernetes.ClientInterface, namespace, pod string) (map[string]string, error) {
+		return nil, nil
+	}
+
+	refs := GetCustomDashboardRefs("bookinfo", "reviews", "v1", []string{"reviews-v1-abc"})
+
+	assert.Len(t, refs, 0)
+}
+
+func TestGetCustomDashboardRefsReturnsEmptyWhenKubeClientFails(t *testing.T) {
+	original := kubeClientFunc
+	defer func() { kubeClientFunc = original }()
+	kubeClientFunc = func() (kubernetes.ClientInterface, error) {
+		return nil, assert.AnError
+	}
+
+	refs := GetCustomDashboardRefs("bookinfo", "reviews", "v1", []string{"reviews-v1-abc"})
+
+	assert.Len(t, refs, 0)
+}
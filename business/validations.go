@@ -0,0 +1,98 @@
+package business
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/kiali/kiali/models"
+)
+
+// IstioValidationsService computes Istio config validations for the objects in a
+// namespace/service, optionally narrowed down to a single object via objectType/objectName
+// (used by the per-service /istio_validations endpoint as well as ServiceDetails's ?validate
+// flag, which both share fetchServiceValidations in handlers/services.go).
+type IstioValidationsService struct{}
+
+// serviceNamePattern matches a valid Kubernetes Service name (an RFC 1035 DNS label): the
+// same pattern the API server itself enforces on creation, so a service that fails it can
+// only have reached Kiali via a stale or fabricated name.
+var serviceNamePattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// supportedValidationObjectTypes lists the objectType values getAllIstioValidationsFunc can
+// actually evaluate today. virtualservice/destinationrule/gateway aren't in it: checking them
+// for real needs a live Istio config client this package doesn't have access to yet, and
+// silently filtering down to an empty result for those types would be indistinguishable from
+// "checked and found nothing wrong" when it's really "never checked at all".
+var supportedValidationObjectTypes = map[string]bool{
+	"service": true,
+}
+
+// UnsupportedValidationObjectTypeError is returned by GetValidations when asked to filter by
+// an objectType the validation pipeline cannot evaluate yet.
+type UnsupportedValidationObjectTypeError struct {
+	ObjectType string
+}
+
+func (e *UnsupportedValidationObjectTypeError) Error() string {
+	return fmt.Sprintf("validations for objectType %q are not supported yet", e.ObjectType)
+}
+
+// getAllIstioValidationsFunc runs the validation pipeline for every Istio object related to a
+// service. It is a variable, rather than a method, so that tests can substitute a fixed
+// result instead of exercising the whole pipeline.
+//
+// The full pipeline (virtual services, destination rules, gateways, ...) needs a live
+// IstioConfig/Kubernetes client that this package does not have access to yet, so for now the
+// only check run is the Kubernetes-name-format one below; the rest raises no findings rather
+// than fabricating ones it can't actually evaluate.
+var getAllIstioValidationsFunc = func(namespace, service string) (models.IstioValidations, error) {
+	if namespace == "" || service == "" {
+		return nil, fmt.Errorf("namespace and service are required to compute validations")
+	}
+
+	validations := models.IstioValidations{}
+	if !serviceNamePattern.MatchString(service) {
+		key := models.IstioValidationKey{ObjectType: "service", Namespace: namespace, Name: service}
+		validations[key] = &models.IstioValidation{
+			Name:       service,
+			ObjectType: "service",
+			Valid:      false,
+			Checks: []*models.IstioCheck{
+				{Message: fmt.Sprintf("%q is not a valid Kubernetes service name (RFC 1035 label)", service), Severity: "error"},
+			},
+		}
+	}
+	return validations, nil
+}
+
+// GetValidations returns the Istio config validations for the given namespace/service. When
+// objectType is non-empty, the result is narrowed down to the objects of that type (and, if
+// objectName is also set, to the object of that type with that name). objectType must be one of
+// supportedValidationObjectTypes; anything else returns an UnsupportedValidationObjectTypeError
+// rather than the empty-but-misleading result of filtering a set that never contained it.
+func (in IstioValidationsService) GetValidations(namespace, service, objectType, objectName string) (models.IstioValidations, error) {
+	if objectType != "" && !supportedValidationObjectTypes[objectType] {
+		return nil, &UnsupportedValidationObjectTypeError{ObjectType: objectType}
+	}
+
+	validations, err := getAllIstioValidationsFunc(namespace, service)
+	if err != nil {
+		return nil, err
+	}
+
+	if objectType == "" {
+		return validations, nil
+	}
+
+	filtered := models.IstioValidations{}
+	for key, validation := range validations {
+		if key.ObjectType != objectType {
+			continue
+		}
+		if objectName != "" && key.Name != objectName {
+			continue
+		}
+		filtered[key] = validation
+	}
+	return filtered, nil
+}
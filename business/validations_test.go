@@ -0,0 +1,93 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+)
+
+func fakeValidations() models.IstioValidations {
+	return models.IstioValidations{
+		models.IstioValidationKey{ObjectType: "service", Namespace: "bookinfo", Name: "reviews"}: {},
+		models.IstioValidationKey{ObjectType: "service", Namespace: "bookinfo", Name: "ratings"}: {},
+	}
+}
+
+func TestGetAllIstioValidationsFuncRequiresNamespaceAndService(t *testing.T) {
+	_, err := getAllIstioValidationsFunc("", "reviews")
+	assert.Error(t, err)
+
+	_, err = getAllIstioValidationsFunc("bookinfo", "")
+	assert.Error(t, err)
+}
+
+func TestGetAllIstioValidationsFuncFlagsInvalidServiceName(t *testing.T) {
+	validations, err := getAllIstioValidationsFunc("bookinfo", "Not_A_Valid_Name")
+
+	assert.NoError(t, err)
+	key := models.IstioValidationKey{ObjectType: "service", Namespace: "bookinfo", Name: "Not_A_Valid_Name"}
+	assert.False(t, validations[key].Valid)
+}
+
+func TestGetAllIstioValidationsFuncAcceptsValidServiceName(t *testing.T) {
+	validations, err := getAllIstioValidationsFunc("bookinfo", "reviews")
+
+	assert.NoError(t, err)
+	assert.Len(t, validations, 0)
+}
+
+func TestGetValidationsNoFilterReturnsEverything(t *testing.T) {
+	original := getAllIstioValidationsFunc
+	defer func() { getAllIstioValidationsFunc = original }()
+	getAllIstioValidationsFunc = func(namespace, service string) (models.IstioValidations, error) {
+		return fakeValidations(), nil
+	}
+
+	validations, err := IstioValidationsService{}.GetValidations("bookinfo", "reviews", "", "")
+
+	assert.NoError(t, err)
+	assert.Len(t, validations, 3)
+}
+
+func TestGetValidationsFiltersByObjectType(t *testing.T) {
+	original := getAllIstioValidationsFunc
+	defer func() { getAllIstioValidationsFunc = original }()
+	getAllIstioValidationsFunc = func(namespace, service string) (models.IstioValidations, error) {
+		return fakeValidations(), nil
+	}
+
+	validations, err := IstioValidationsService{}.GetValidations("bookinfo", "reviews", "service", "")
+
+	assert.NoError(t, err)
+	assert.Len(t, validations, 2)
+}
+
+func TestGetValidationsFiltersByObjectTypeAndName(t *testing.T) {
+	original := getAllIstioValidationsFunc
+	defer func() { getAllIstioValidationsFunc = original }()
+	getAllIstioValidationsFunc = func(namespace, service string) (models.IstioValidations, error) {
+		return fakeValidations(), nil
+	}
+
+	validations, err := IstioValidationsService{}.GetValidations("bookinfo", "reviews", "service", "ratings")
+
+	assert.NoError(t, err)
+	assert.Len(t, validations, 1)
+}
+
+func TestGetValidationsRejectsUnsupportedObjectType(t *testing.T) {
+	original := getAllIstioValidationsFunc
+	defer func() { getAllIstioValidationsFunc = original }()
+	getAllIstioValidationsFunc = func(namespace, service string) (models.IstioValidations, error) {
+		return fakeValidations(), nil
+	}
+
+	for _, objectType := range []string{"virtualservice", "destinationrule", "gateway"} {
+		_, err := IstioValidationsService{}.GetValidations("bookinfo", "reviews", objectType, "")
+
+		var unsupportedTypeErr *UnsupportedValidationObjectTypeError
+		assert.ErrorAs(t, err, &unsupportedTypeErr, "objectType %q should be rejected, not silently filtered to empty", objectType)
+	}
+}
@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"encoding/json"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+)
+
+// jsonCodec implements encoding.Codec using JSON instead of protobuf, so this package's
+// hand-written message structs (see messages.go) can be used without real protoc-generated
+// proto.Message implementations. It registers under the "json" content-subtype rather than
+// gRPC's default "proto" one: squatting "proto" would silently reroute any other protobuf
+// traffic sharing this process through json.Marshal/Unmarshal, and would make the advertised
+// .proto wire-incompatible with a genuine generated client. Use ServerOption below to make a
+// *grpc.Server built for KialiService use it.
+type jsonCodec struct{}
+
+func (jsonCodec) Marshal(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+func (jsonCodec) Name() string {
+	return "json"
+}
+
+func init() {
+	encoding.RegisterCodec(jsonCodec{})
+}
+
+// ServerOption forces a *grpc.Server to encode/decode every RPC with jsonCodec, regardless of
+// the content-subtype a client requests. Pass it to grpc.NewServer when constructing the
+// server KialiService is registered on:
+//
+//	grpcServer := grpc.NewServer(ServerOption())
+//	grpc.Register(grpcServer)
+//
+// Without it, a *grpc.Server falls back to gRPC's built-in protobuf codec under the default
+// "proto" content-subtype, which cannot marshal the plain structs in messages.go.
+func ServerOption() grpc.ServerOption {
+	return grpc.ForceServerCodec(jsonCodec{})
+}
@@ -0,0 +1,29 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONCodecRoundTrips(t *testing.T) {
+	codec := jsonCodec{}
+	original := &ServiceSummary{Namespace: "bookinfo", Name: "reviews", HealthStatus: "healthy"}
+
+	data, err := codec.Marshal(original)
+	assert.NoError(t, err)
+
+	var decoded ServiceSummary
+	assert.NoError(t, codec.Unmarshal(data, &decoded))
+	assert.Equal(t, *original, decoded)
+}
+
+func TestJSONCodecNameIsDistinctFromDefaultContentSubtype(t *testing.T) {
+	assert.Equal(t, "json", jsonCodec{}.Name())
+}
+
+func TestServerOptionIsNotNil(t *testing.T) {
+	// grpc.ServerOption only exposes its effect through an unexported field, so this just
+	// guards against ServerOption panicking or returning a nil option.
+	assert.NotNil(t, ServerOption())
+}
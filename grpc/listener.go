@@ -0,0 +1,46 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/soheilhy/cmux"
+	"google.golang.org/grpc"
+
+	"github.com/kiali/kiali/log"
+)
+
+// Serve splits l with cmux so that gRPC and plain HTTP/1.1 traffic share the same port:
+// requests advertising the "application/grpc" content-type are routed to grpcServer, and
+// everything else to httpServer. Both are served until l is closed. As soon as any one of
+// grpcServer, httpServer or the cmux dispatcher stops (error or otherwise), the other two
+// are shut down too so no goroutine is left running after Serve returns.
+//
+// Serve itself isn't called from the server's startup path yet (see the package comment in
+// server.go) — that path constructs its own net.Listener/*http.Server today and would need to
+// be changed to build a *grpc.Server with ServerOption() (codec.go), Register it (server.go),
+// and call Serve with all three instead of calling httpServer.Serve(l) directly.
+func Serve(l net.Listener, grpcServer *grpc.Server, httpServer *http.Server) error {
+	m := cmux.New(l)
+	grpcListener := m.Match(cmux.HTTP2HeaderField("content-type", "application/grpc"))
+	httpListener := m.Match(cmux.Any())
+
+	errCh := make(chan error, 3)
+	go func() { errCh <- grpcServer.Serve(grpcListener) }()
+	go func() { errCh <- httpServer.Serve(httpListener) }()
+	go func() { errCh <- m.Serve() }()
+
+	err := <-errCh
+	if err != nil {
+		log.Errorf("grpc.Serve: %s", err.Error())
+	}
+
+	// Whichever of the three stopped first, stop the remaining two so nothing is left
+	// running in the background once Serve returns.
+	grpcServer.GracefulStop()
+	_ = httpServer.Shutdown(context.Background())
+	m.Close()
+
+	return err
+}
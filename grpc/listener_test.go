@@ -0,0 +1,42 @@
+package grpc
+
+import (
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+// TestServeStopsEverythingWhenListenerCloses verifies that closing the shared listener
+// makes Serve return (which it does only after stopping the gRPC server, the HTTP server
+// and the cmux dispatcher), and that nothing accepts on the listener's address afterwards.
+func TestServeStopsEverythingWhenListenerCloses(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	grpcServer := grpc.NewServer()
+	httpServer := &http.Server{Handler: http.NewServeMux()}
+
+	done := make(chan error, 1)
+	go func() { done <- Serve(l, grpcServer, httpServer) }()
+
+	// Give the three Serve goroutines a moment to start accepting before closing.
+	time.Sleep(50 * time.Millisecond)
+	assert.NoError(t, l.Close())
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve did not return after the listener was closed")
+	}
+
+	// A new connection attempt to the closed listener's address should now fail.
+	conn, dialErr := net.DialTimeout("tcp", l.Addr().String(), 100*time.Millisecond)
+	if dialErr == nil {
+		conn.Close()
+		t.Fatal("expected no listener to remain after Serve returned")
+	}
+}
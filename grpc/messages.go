@@ -0,0 +1,75 @@
+package grpc
+
+// The message types below mirror the ones defined in kiali.proto. They are plain Go
+// structs rather than protoc-generated proto.Message implementations: codec.go registers a
+// JSON codec under the "json" gRPC content-subtype (see codec.go's ServerOption), so these
+// structs only need to be JSON-(un)marshalable, not satisfy the protobuf runtime. Once
+// `protoc` is wired into the build, these can be replaced by the generated kialipb package
+// without changing any handler code in server.go.
+
+// ListServicesRequest is the request for KialiService.ListServices.
+type ListServicesRequest struct {
+	Namespaces []string `json:"namespaces"`
+}
+
+// ServiceSummary is a single entry streamed back by KialiService.ListServices.
+type ServiceSummary struct {
+	Namespace    string `json:"namespace"`
+	Name         string `json:"name"`
+	HealthStatus string `json:"healthStatus"`
+}
+
+// ServiceDetailsRequest is the request for KialiService.GetServiceDetails.
+type ServiceDetailsRequest struct {
+	Namespace    string `json:"namespace"`
+	Service      string `json:"service"`
+	RateInterval string `json:"rateInterval"`
+}
+
+// ServiceDetails is the response for KialiService.GetServiceDetails.
+type ServiceDetails struct {
+	Summary     *ServiceSummary `json:"summary"`
+	DetailsJSON []byte          `json:"detailsJson"`
+}
+
+// ServiceMetricsRequest is the request for KialiService.GetServiceMetrics.
+type ServiceMetricsRequest struct {
+	Namespace    string `json:"namespace"`
+	Service      string `json:"service"`
+	RateInterval string `json:"rateInterval"`
+	Duration     string `json:"duration"`
+	Step         string `json:"step"`
+}
+
+// ServiceMetrics is the response for KialiService.GetServiceMetrics.
+type ServiceMetrics struct {
+	MetricsJSON []byte `json:"metricsJson"`
+}
+
+// ServiceDashboardRequest is the request for KialiService.GetServiceDashboard.
+type ServiceDashboardRequest struct {
+	Namespace    string `json:"namespace"`
+	Service      string `json:"service"`
+	RateInterval string `json:"rateInterval"`
+}
+
+// ServiceDashboard is the response for KialiService.GetServiceDashboard.
+type ServiceDashboard struct {
+	DashboardJSON []byte `json:"dashboardJson"`
+}
+
+// ServiceHealthSubscription is sent by the client on the KialiService.WatchServiceHealth
+// stream to add (or, if Unsubscribe is set, remove) a watched service.
+type ServiceHealthSubscription struct {
+	Namespace   string `json:"namespace"`
+	Service     string `json:"service"`
+	Unsubscribe bool   `json:"unsubscribe"`
+}
+
+// ServiceHealthUpdate is sent by the server on the KialiService.WatchServiceHealth stream
+// whenever a watched service's health is (re)computed.
+type ServiceHealthUpdate struct {
+	Namespace    string `json:"namespace"`
+	Service      string `json:"service"`
+	HealthStatus string `json:"healthStatus"`
+}
@@ -0,0 +1,257 @@
+// Package grpc exposes the service-centric REST handlers in handlers/services.go as the
+// KialiService gRPC service defined in kiali.proto. The message types and service glue
+// (messages.go, service.go, codec.go) are hand-written rather than protoc-generated, since
+// this package has no protoc/protoc-gen-go-grpc build step yet; they can be swapped for a
+// generated kialipb package later without changing this file.
+//
+// Nothing in this package is wired into the running server yet: Register and Serve are ready
+// to be called, but the server's startup path (where the shared net.Listener, *grpc.Server
+// and *http.Server are constructed today) lives outside this package and hasn't been updated
+// to call them. Until that call is added, KialiService is unreachable.
+package grpc
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/prometheus"
+	"github.com/kiali/kiali/util"
+)
+
+// defaultHealthWatchInterval is how often WatchServiceHealth recomputes health for every
+// currently watched service.
+const defaultHealthWatchInterval = 10 * time.Second
+
+// defaultHealthRateInterval is the rate interval used for health computations when a request
+// doesn't specify one, matching handlers.defaultHealthRateInterval's "1m" default for the REST API.
+const defaultHealthRateInterval = "1m"
+
+// Server implements KialiServiceServer by delegating to the same business layer used by
+// the REST handlers, so both surfaces stay consistent.
+type Server struct {
+	UnimplementedKialiServiceServer
+}
+
+// NewServer creates a Server ready to be registered on a grpc.Server.
+func NewServer() *Server {
+	return &Server{}
+}
+
+// metricsProviderFunc resolves the prometheus.MetricsProvider used by GetServiceMetrics and
+// GetServiceDashboard. It is a variable, analogous to handlers.defaultMetricsClientSupplier,
+// so tests can substitute a stub instead of requiring a real metrics backend.
+var metricsProviderFunc = func() (prometheus.MetricsProvider, error) {
+	cfg := config.Get().ExternalServices
+	return prometheus.NewMetricsProvider(cfg.MetricsBackend, cfg.Prometheus, cfg.MetricsBackendURL)
+}
+
+// Register registers the KialiService on the given gRPC server. s must have been built with
+// ServerOption() (see codec.go) so it encodes/decodes KialiService RPCs with jsonCodec
+// instead of falling back to gRPC's built-in protobuf codec.
+func Register(s *grpc.Server) {
+	RegisterKialiServiceServer(s, NewServer())
+}
+
+// ListServices streams a ServiceSummary for every service in each requested namespace,
+// sending results as each namespace's fetch completes instead of waiting on all of them.
+func (s *Server) ListServices(req *ListServicesRequest, stream KialiService_ListServicesServer) error {
+	businessLayer, err := business.Get()
+	if err != nil {
+		return err
+	}
+
+	for _, namespace := range req.Namespaces {
+		serviceList, err := businessLayer.Svc.GetServiceList(namespace)
+		if err != nil {
+			return err
+		}
+
+		for _, svc := range serviceList.Services {
+			summary := &ServiceSummary{
+				Namespace:    namespace,
+				Name:         svc.Name,
+				HealthStatus: svc.Health.GetGlobalStatus().Name,
+			}
+			if err := stream.Send(summary); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// GetServiceDetails mirrors handlers.ServiceDetails, returning the service detail payload
+// JSON-encoded so the wire format can evolve without changes to the .proto.
+func (s *Server) GetServiceDetails(ctx context.Context, req *ServiceDetailsRequest) (*ServiceDetails, error) {
+	businessLayer, err := business.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	rateInterval := req.RateInterval
+	if rateInterval == "" {
+		rateInterval = defaultHealthRateInterval
+	}
+
+	queryTime := util.Clock.Now()
+	serviceDetails, err := businessLayer.Svc.GetService(req.Namespace, req.Service, rateInterval, queryTime)
+	if err != nil {
+		return nil, err
+	}
+
+	detailsJSON, err := json.Marshal(serviceDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceDetails{
+		Summary: &ServiceSummary{
+			Namespace: req.Namespace,
+			Name:      req.Service,
+		},
+		DetailsJSON: detailsJSON,
+	}, nil
+}
+
+// GetServiceMetrics mirrors handlers.ServiceMetrics, returning the metrics JSON-encoded so
+// the wire format can evolve without changes to the .proto. The query is built from
+// req.Namespace and req.Service only; req.RateInterval, req.Duration and req.Step are not
+// applied to it.
+func (s *Server) GetServiceMetrics(ctx context.Context, req *ServiceMetricsRequest) (*ServiceMetrics, error) {
+	prom, err := metricsProviderFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	query := prometheus.IstioMetricsQuery{Namespace: req.Namespace, Service: req.Service}
+	metrics := prom.GetMetrics(&query)
+
+	metricsJSON, err := json.Marshal(metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceMetrics{MetricsJSON: metricsJSON}, nil
+}
+
+// GetServiceDashboard mirrors handlers.ServiceDashboard, returning the Istio dashboard
+// JSON-encoded so the wire format can evolve without changes to the .proto. The query is
+// built from req.Namespace and req.Service only; req.RateInterval is not applied to it.
+func (s *Server) GetServiceDashboard(ctx context.Context, req *ServiceDashboardRequest) (*ServiceDashboard, error) {
+	prom, err := metricsProviderFunc()
+	if err != nil {
+		return nil, err
+	}
+
+	query := prometheus.IstioMetricsQuery{Namespace: req.Namespace, Service: req.Service}
+	dashboard, err := business.NewDashboardsService(prom).GetIstioDashboard(query)
+	if err != nil {
+		return nil, err
+	}
+
+	dashboardJSON, err := json.Marshal(dashboard)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServiceDashboard{DashboardJSON: dashboardJSON}, nil
+}
+
+// WatchServiceHealth is a bidi stream: the client sends ServiceHealthSubscription messages
+// to add or remove watched services, and receives a ServiceHealthUpdate whenever a watched
+// service's health changes. Health is recomputed for every watched service on every tick, but
+// only services whose global status actually differs from the last update sent are written to
+// the stream, so a client sees a change-triggered feed rather than a fixed-interval poll of
+// everything it watches.
+func (s *Server) WatchServiceHealth(stream KialiService_WatchServiceHealthServer) error {
+	businessLayer, err := business.Get()
+	if err != nil {
+		return err
+	}
+
+	watched := make(map[[2]string]bool)
+	// lastHealthStatus holds the global status last sent for a watched service, so a tick
+	// that recomputes the same status doesn't re-send it. A key's absence (on first watch,
+	// or right after a resubscribe) means "no update sent yet", so the first tick after
+	// watching a service always reports its current status.
+	lastHealthStatus := make(map[[2]string]string)
+	ticker := time.NewTicker(defaultHealthWatchInterval)
+	defer ticker.Stop()
+
+	subscriptions := make(chan *ServiceHealthSubscription)
+	go func() {
+		defer close(subscriptions)
+		for {
+			sub, err := stream.Recv()
+			if err == io.EOF {
+				return
+			}
+			if err != nil {
+				log.Errorf("WatchServiceHealth: recv error: %s", err.Error())
+				return
+			}
+			subscriptions <- sub
+		}
+	}()
+
+	for {
+		select {
+		case sub, ok := <-subscriptions:
+			if !ok {
+				return nil
+			}
+			key := [2]string{sub.Namespace, sub.Service}
+			if sub.Unsubscribe {
+				delete(watched, key)
+				delete(lastHealthStatus, key)
+			} else {
+				watched[key] = true
+			}
+		case <-ticker.C:
+			for key := range watched {
+				health, err := businessLayer.Health.GetServiceHealth(key[0], key[1], defaultHealthRateInterval, util.Clock.Now())
+				if err != nil {
+					log.Errorf("WatchServiceHealth: %s", err.Error())
+					continue
+				}
+
+				status := health.GetGlobalStatus().Name
+				if !healthStatusChanged(lastHealthStatus, key, status) {
+					continue
+				}
+
+				update := &ServiceHealthUpdate{
+					Namespace:    key[0],
+					Service:      key[1],
+					HealthStatus: status,
+				}
+				if err := stream.Send(update); err != nil {
+					return err
+				}
+			}
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		}
+	}
+}
+
+// healthStatusChanged reports whether status is new information for key given
+// lastHealthStatus (the status last sent for each watched service): true the first time a key
+// is seen, or whenever status differs from what was last recorded. As a side effect, it
+// records status as the new last-sent value whenever it returns true, so the next call with
+// an unchanged status returns false. This is the decision that makes WatchServiceHealth a
+// change-triggered stream rather than a fixed-interval poll of every watched service.
+func healthStatusChanged(lastHealthStatus map[[2]string]string, key [2]string, status string) bool {
+	if previous, sent := lastHealthStatus[key]; sent && previous == status {
+		return false
+	}
+	lastHealthStatus[key] = status
+	return true
+}
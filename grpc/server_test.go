@@ -0,0 +1,43 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthStatusChangedIsTrueOnFirstSight(t *testing.T) {
+	lastHealthStatus := make(map[[2]string]string)
+	key := [2]string{"bookinfo", "reviews"}
+
+	assert.True(t, healthStatusChanged(lastHealthStatus, key, "Healthy"))
+	assert.Equal(t, "Healthy", lastHealthStatus[key])
+}
+
+func TestHealthStatusChangedIsFalseWhenStatusIsUnchanged(t *testing.T) {
+	lastHealthStatus := make(map[[2]string]string)
+	key := [2]string{"bookinfo", "reviews"}
+
+	assert.True(t, healthStatusChanged(lastHealthStatus, key, "Healthy"))
+	assert.False(t, healthStatusChanged(lastHealthStatus, key, "Healthy"))
+}
+
+func TestHealthStatusChangedIsTrueWhenStatusDiffers(t *testing.T) {
+	lastHealthStatus := make(map[[2]string]string)
+	key := [2]string{"bookinfo", "reviews"}
+
+	assert.True(t, healthStatusChanged(lastHealthStatus, key, "Healthy"))
+	assert.True(t, healthStatusChanged(lastHealthStatus, key, "Failure"))
+	assert.Equal(t, "Failure", lastHealthStatus[key])
+}
+
+func TestHealthStatusChangedTracksMultipleKeysIndependently(t *testing.T) {
+	lastHealthStatus := make(map[[2]string]string)
+	reviews := [2]string{"bookinfo", "reviews"}
+	ratings := [2]string{"bookinfo", "ratings"}
+
+	assert.True(t, healthStatusChanged(lastHealthStatus, reviews, "Healthy"))
+	assert.True(t, healthStatusChanged(lastHealthStatus, ratings, "Healthy"))
+	assert.False(t, healthStatusChanged(lastHealthStatus, reviews, "Healthy"))
+	assert.True(t, healthStatusChanged(lastHealthStatus, ratings, "Degraded"))
+}
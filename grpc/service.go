@@ -0,0 +1,178 @@
+package grpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// KialiServiceServer is the server API for the KialiService defined in kiali.proto.
+type KialiServiceServer interface {
+	ListServices(*ListServicesRequest, KialiService_ListServicesServer) error
+	GetServiceDetails(context.Context, *ServiceDetailsRequest) (*ServiceDetails, error)
+	GetServiceMetrics(context.Context, *ServiceMetricsRequest) (*ServiceMetrics, error)
+	GetServiceDashboard(context.Context, *ServiceDashboardRequest) (*ServiceDashboard, error)
+	WatchServiceHealth(KialiService_WatchServiceHealthServer) error
+	mustEmbedUnimplementedKialiServiceServer()
+}
+
+// UnimplementedKialiServiceServer must be embedded by every KialiServiceServer
+// implementation for forward compatibility: a method added to the interface later gets a
+// default "unimplemented" body instead of breaking existing implementations.
+type UnimplementedKialiServiceServer struct{}
+
+func (UnimplementedKialiServiceServer) ListServices(*ListServicesRequest, KialiService_ListServicesServer) error {
+	return status.Errorf(codes.Unimplemented, "method ListServices not implemented")
+}
+
+func (UnimplementedKialiServiceServer) GetServiceDetails(context.Context, *ServiceDetailsRequest) (*ServiceDetails, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceDetails not implemented")
+}
+
+func (UnimplementedKialiServiceServer) GetServiceMetrics(context.Context, *ServiceMetricsRequest) (*ServiceMetrics, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceMetrics not implemented")
+}
+
+func (UnimplementedKialiServiceServer) GetServiceDashboard(context.Context, *ServiceDashboardRequest) (*ServiceDashboard, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetServiceDashboard not implemented")
+}
+
+func (UnimplementedKialiServiceServer) WatchServiceHealth(KialiService_WatchServiceHealthServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchServiceHealth not implemented")
+}
+
+func (UnimplementedKialiServiceServer) mustEmbedUnimplementedKialiServiceServer() {}
+
+// KialiService_ListServicesServer is the server-side stream for ListServices.
+type KialiService_ListServicesServer interface {
+	Send(*ServiceSummary) error
+	grpc.ServerStream
+}
+
+type kialiServiceListServicesServer struct {
+	grpc.ServerStream
+}
+
+func (x *kialiServiceListServicesServer) Send(m *ServiceSummary) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// KialiService_WatchServiceHealthServer is the server-side stream for WatchServiceHealth.
+type KialiService_WatchServiceHealthServer interface {
+	Send(*ServiceHealthUpdate) error
+	Recv() (*ServiceHealthSubscription, error)
+	grpc.ServerStream
+}
+
+type kialiServiceWatchServiceHealthServer struct {
+	grpc.ServerStream
+}
+
+func (x *kialiServiceWatchServiceHealthServer) Send(m *ServiceHealthUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *kialiServiceWatchServiceHealthServer) Recv() (*ServiceHealthSubscription, error) {
+	m := new(ServiceHealthSubscription)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+var kialiServiceServiceDesc = grpc.ServiceDesc{
+	ServiceName: "kiali.grpc.KialiService",
+	HandlerType: (*KialiServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetServiceDetails",
+			Handler:    kialiServiceGetServiceDetailsHandler,
+		},
+		{
+			MethodName: "GetServiceMetrics",
+			Handler:    kialiServiceGetServiceMetricsHandler,
+		},
+		{
+			MethodName: "GetServiceDashboard",
+			Handler:    kialiServiceGetServiceDashboardHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListServices",
+			Handler:       kialiServiceListServicesHandler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchServiceHealth",
+			Handler:       kialiServiceWatchServiceHealthHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "kiali.proto",
+}
+
+// RegisterKialiServiceServer registers srv as the implementation of the KialiService on s.
+func RegisterKialiServiceServer(s *grpc.Server, srv KialiServiceServer) {
+	s.RegisterService(&kialiServiceServiceDesc, srv)
+}
+
+func kialiServiceGetServiceDetailsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceDetailsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KialiServiceServer).GetServiceDetails(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/kiali.grpc.KialiService/GetServiceDetails"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KialiServiceServer).GetServiceDetails(ctx, req.(*ServiceDetailsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func kialiServiceListServicesHandler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListServicesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(KialiServiceServer).ListServices(m, &kialiServiceListServicesServer{stream})
+}
+
+func kialiServiceWatchServiceHealthHandler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(KialiServiceServer).WatchServiceHealth(&kialiServiceWatchServiceHealthServer{stream})
+}
+
+func kialiServiceGetServiceMetricsHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceMetricsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KialiServiceServer).GetServiceMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/kiali.grpc.KialiService/GetServiceMetrics"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KialiServiceServer).GetServiceMetrics(ctx, req.(*ServiceMetricsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func kialiServiceGetServiceDashboardHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceDashboardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KialiServiceServer).GetServiceDashboard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{Server: srv, FullMethod: "/kiali.grpc.KialiService/GetServiceDashboard"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KialiServiceServer).GetServiceDashboard(ctx, req.(*ServiceDashboardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/kiali/kiali/prometheus"
+)
+
+// AppMetrics is the API handler to fetch metrics to be displayed, related to a single app.
+func AppMetrics(w http.ResponseWriter, r *http.Request) {
+	getAppMetrics(w, r, defaultMetricsClientSupplier)
+}
+
+// getAppMetrics (mock-friendly version)
+func getAppMetrics(w http.ResponseWriter, r *http.Request, metricsSupplier metricsClientSupplier) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	app := vars["app"]
+
+	prom, namespaceInfo := initClientsForMetrics(w, r, metricsSupplier, namespace)
+	if prom == nil {
+		// any returned value nil means error & response already written
+		return
+	}
+	forwardOriginalQuery(prom, r)
+
+	params := prometheus.IstioMetricsQuery{Namespace: namespace, App: app}
+	if err := extractIstioMetricsQueryParams(r, &params, namespaceInfo); err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	metrics := prom.GetMetrics(&params)
+	RespondWithJSON(w, http.StatusOK, metrics)
+}
+
+// AppMetricsStream streams live metrics and health for a single app as Server-Sent Events,
+// on the same wire contract as ServiceMetricsStream (periodic "data:" snapshots, Last-Event-ID
+// resume support).
+func AppMetricsStream(w http.ResponseWriter, r *http.Request) {
+	streamAppMetrics(w, r, defaultMetricsClientSupplier)
+}
+
+// streamAppMetrics (mock-friendly version)
+func streamAppMetrics(w http.ResponseWriter, r *http.Request, metricsSupplier metricsClientSupplier) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	app := vars["app"]
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	streamMetrics(w, r, metricsSupplier, namespace, streamMetricsConfig{
+		query: prometheus.IstioMetricsQuery{Namespace: namespace, App: app},
+		fetchHealth: func(rateInterval string, queryTime time.Time) (interface{}, error) {
+			return business.Health.GetAppHealth(namespace, app, rateInterval, queryTime)
+		},
+	})
+}
@@ -1,17 +1,184 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/labels"
 
 	"github.com/kiali/kiali/business"
+	"github.com/kiali/kiali/config"
 	"github.com/kiali/kiali/models"
 	"github.com/kiali/kiali/prometheus"
 	"github.com/kiali/kiali/util"
 )
 
+// metricsClientSupplier resolves the prometheus.MetricsProvider to use for a request.
+// Tests substitute a mock implementation here instead of hitting a real backend.
+type metricsClientSupplier func() (prometheus.MetricsProvider, error)
+
+// defaultMetricsClientSupplier builds the MetricsProvider selected via the
+// "metrics_backend" server config ("prometheus", "thanos" or "remote").
+func defaultMetricsClientSupplier() (prometheus.MetricsProvider, error) {
+	cfg := config.Get().ExternalServices
+	return prometheus.NewMetricsProvider(cfg.MetricsBackend, cfg.Prometheus, cfg.MetricsBackendURL)
+}
+
+// forwardOriginalQuery passes r's raw query string through to prom, when prom supports
+// forwarding it. Today that's only *prometheus.RemoteMetricsClient: a remote metrics_backend
+// proxies the request to an external gateway, and should forward every filter the client
+// originally asked for (step, rateFunc, direction, reporter, quantiles, byLabels, ...), not
+// just the time window the business layer recomputes into IstioMetricsQuery's Start/End.
+func forwardOriginalQuery(prom prometheus.MetricsProvider, r *http.Request) {
+	if remote, ok := prom.(*prometheus.RemoteMetricsClient); ok {
+		remote.SetForwardedQuery(r.URL.RawQuery)
+	}
+}
+
+const (
+	// defaultServiceListPageSize is used when the client does not supply a "pageSize"
+	// query parameter on the multi-namespace service list endpoint.
+	defaultServiceListPageSize = 20
+	// maxConcurrentNamespaceFetches bounds how many namespaces ServiceListMulti fans
+	// out to business.Svc.GetServiceList concurrently.
+	maxConcurrentNamespaceFetches = 10
+	// defaultHealthRateInterval is used by resolveRateInterval when a request does not
+	// supply a "rateInterval" query parameter, for health computations.
+	defaultHealthRateInterval = "1m"
+)
+
+// ServiceListPage is a page of services gathered across one or more namespaces.
+type ServiceListPage struct {
+	Items    []models.ServiceOverview `json:"items"`
+	Total    int                      `json:"total"`
+	Page     int                      `json:"page"`
+	PageSize int                      `json:"pageSize"`
+}
+
+// defaultMetricsStreamInterval is used when the client does not supply a
+// "streamInterval" query parameter on a metrics SSE endpoint.
+const defaultMetricsStreamInterval = 15 * time.Second
+
+// metricsStreamSnapshot is the payload pushed on every "data:" event of a
+// metrics SSE stream: the raw Prometheus metrics plus the health computed
+// for the same point in time. Health is typed as interface{} rather than, say,
+// models.ServiceHealth, since streamMetrics is shared by the service/app/workload metrics
+// streams and each computes a different concrete health type; the JSON it marshals to is
+// the same either way.
+type metricsStreamSnapshot struct {
+	Metrics prometheus.Metrics `json:"metrics"`
+	Health  interface{}        `json:"health"`
+}
+
+// streamMetricsConfig parameterizes streamMetrics for a single entity kind (service, app or
+// workload): query seeds the IstioMetricsQuery with that entity's identifying field(s), and
+// fetchHealth computes its health for a given rateInterval/queryTime.
+type streamMetricsConfig struct {
+	query       prometheus.IstioMetricsQuery
+	fetchHealth func(rateInterval string, queryTime time.Time) (interface{}, error)
+}
+
+// streamMetrics drives the periodic "data:" SSE loop shared by streamServiceMetrics,
+// streamAppMetrics and streamWorkloadMetrics: ticker cadence, Last-Event-ID resume and event
+// framing are identical across the three; cfg supplies the one thing that differs, how to
+// seed the metrics query and compute health for the streamed entity.
+func streamMetrics(w http.ResponseWriter, r *http.Request, metricsSupplier metricsClientSupplier, namespace string, cfg streamMetricsConfig) {
+	prom, namespaceInfo := initClientsForMetrics(w, r, metricsSupplier, namespace)
+	if prom == nil {
+		// any returned value nil means error & response already written
+		return
+	}
+	forwardOriginalQuery(prom, r)
+
+	params := cfg.query
+	if err := extractIstioMetricsQueryParams(r, &params, namespaceInfo); err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	// Health is computed over the same rateInterval as the streamed metrics, so the two
+	// figures in every snapshot describe the same window.
+	rateInterval := resolveRateInterval(r)
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		RespondWithError(w, http.StatusInternalServerError, "Streaming unsupported by this connection")
+		return
+	}
+
+	interval := defaultMetricsStreamInterval
+	if raw := r.URL.Query().Get("streamInterval"); raw != "" {
+		if parsed, parseErr := time.ParseDuration(raw); parseErr == nil && parsed > 0 {
+			interval = parsed
+		}
+	}
+
+	// Event IDs are the Unix timestamp (seconds) of the snapshot that produced them, so a
+	// client reconnecting with Last-Event-ID can resume from exactly where it left off: we
+	// seed params.Start with that timestamp instead of the current instant, so the first
+	// snapshot after reconnecting covers the gap while the client was disconnected rather
+	// than silently dropping it.
+	if resumeFrom, ok := resolveStreamResumeStart(r); ok {
+		params.Start = resumeFrom
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	streamMetricsLoop(r.Context(), w, flusher, prom, params, rateInterval, interval, cfg.fetchHealth)
+}
+
+// streamMetricsLoop runs the periodic snapshot loop at the heart of streamMetrics: every tick,
+// it fetches metrics and health for the same point in time, advances the query window, and
+// writes a "data:" event framed the way a reconnecting client's Last-Event-ID expects. It's
+// split out from streamMetrics so the loop itself can be exercised in tests without needing a
+// real metricsClientSupplier or business.Layer.
+func streamMetricsLoop(ctx context.Context, w http.ResponseWriter, flusher http.Flusher, prom prometheus.MetricsProvider, params prometheus.IstioMetricsQuery, rateInterval string, interval time.Duration, fetchHealth func(rateInterval string, queryTime time.Time) (interface{}, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			queryTime := util.Clock.Now()
+			params.End = queryTime
+
+			metrics := prom.GetMetrics(&params)
+			health, err := fetchHealth(rateInterval, queryTime)
+			if err != nil {
+				fmt.Fprintf(w, "event: error\ndata: %s\n\n", err.Error())
+				flusher.Flush()
+				continue
+			}
+
+			data, err := json.Marshal(metricsStreamSnapshot{Metrics: metrics, Health: health})
+			if err != nil {
+				continue
+			}
+
+			fmt.Fprintf(w, "id: %d\ndata: %s\n\n", queryTime.Unix(), data)
+			flusher.Flush()
+
+			params.Start = params.End
+		}
+	}
+}
+
 // ServiceList is the API handler to fetch the list of services in a given namespace
 func ServiceList(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
@@ -34,22 +201,72 @@ func ServiceList(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, serviceList)
 }
 
+// ServiceListMulti is the API handler to fetch a paginated, server-side filtered list of
+// services across one or more namespaces (?namespaces=ns1,ns2 or ?namespaces=all).
+func ServiceListMulti(w http.ResponseWriter, r *http.Request) {
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	queryParams := r.URL.Query()
+
+	namespaces, err := resolveRequestedNamespaces(businessLayer, queryParams.Get("namespaces"))
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	labelSelector := queryParams.Get("labelSelector")
+	overviews, err := fetchServiceOverviews(businessLayer, namespaces, labelSelector)
+	if err != nil {
+		var selectorErr *invalidLabelSelectorError
+		if errors.As(err, &selectorErr) {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		handleErrorResponse(w, err)
+		return
+	}
+
+	if nameContains := queryParams.Get("nameContains"); nameContains != "" {
+		overviews = filterServicesByName(overviews, nameContains)
+	}
+	if health := queryParams.Get("health"); health != "" {
+		overviews = filterServicesByHealth(overviews, health)
+	}
+
+	sortServiceOverviews(overviews, queryParams.Get("sortBy"))
+
+	page, pageSize := parseServiceListPagination(queryParams)
+	total := len(overviews)
+
+	RespondWithJSON(w, http.StatusOK, ServiceListPage{
+		Items:    paginateServiceOverviews(overviews, page, pageSize),
+		Total:    total,
+		Page:     page,
+		PageSize: pageSize,
+	})
+}
+
 // ServiceMetrics is the API handler to fetch metrics to be displayed, related to a single service
 func ServiceMetrics(w http.ResponseWriter, r *http.Request) {
-	getServiceMetrics(w, r, defaultPromClientSupplier)
+	getServiceMetrics(w, r, defaultMetricsClientSupplier)
 }
 
 // getServiceMetrics (mock-friendly version)
-func getServiceMetrics(w http.ResponseWriter, r *http.Request, promSupplier promClientSupplier) {
+func getServiceMetrics(w http.ResponseWriter, r *http.Request, metricsSupplier metricsClientSupplier) {
 	vars := mux.Vars(r)
 	namespace := vars["namespace"]
 	service := vars["service"]
 
-	prom, namespaceInfo := initClientsForMetrics(w, r, promSupplier, namespace)
+	prom, namespaceInfo := initClientsForMetrics(w, r, metricsSupplier, namespace)
 	if prom == nil {
 		// any returned value nil means error & response already written
 		return
 	}
+	forwardOriginalQuery(prom, r)
 
 	params := prometheus.IstioMetricsQuery{Namespace: namespace, Service: service}
 	err := extractIstioMetricsQueryParams(r, &params, namespaceInfo)
@@ -62,6 +279,59 @@ func getServiceMetrics(w http.ResponseWriter, r *http.Request, promSupplier prom
 	RespondWithJSON(w, http.StatusOK, metrics)
 }
 
+// ServiceMetricsStream is the API handler that streams metrics and health for a
+// single service over a Server-Sent Events connection, so that dashboards can
+// follow live data without repeatedly polling ServiceMetrics.
+func ServiceMetricsStream(w http.ResponseWriter, r *http.Request) {
+	streamServiceMetrics(w, r, defaultMetricsClientSupplier)
+}
+
+// streamServiceMetrics (mock-friendly version)
+func streamServiceMetrics(w http.ResponseWriter, r *http.Request, metricsSupplier metricsClientSupplier) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	service := vars["service"]
+
+	business, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	streamMetrics(w, r, metricsSupplier, namespace, streamMetricsConfig{
+		query: prometheus.IstioMetricsQuery{Namespace: namespace, Service: service},
+		fetchHealth: func(rateInterval string, queryTime time.Time) (interface{}, error) {
+			return business.Health.GetServiceHealth(namespace, service, rateInterval, queryTime)
+		},
+	})
+}
+
+// resolveStreamResumeStart parses the Last-Event-ID header sent by a reconnecting SSE
+// client — the Unix timestamp (seconds) of the last snapshot it received, as set by
+// streamMetricsLoop's "id:" field — into the time a resumed stream's query window should
+// start from. ok is false when the header is absent or not a valid event ID, in which case
+// the caller should leave the window as a fresh connection would see it.
+func resolveStreamResumeStart(r *http.Request) (time.Time, bool) {
+	lastID := r.Header.Get("Last-Event-ID")
+	if lastID == "" {
+		return time.Time{}, false
+	}
+	resumeUnix, err := strconv.ParseInt(lastID, 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(resumeUnix, 0), true
+}
+
+// resolveRateInterval reads the "rateInterval" query parameter shared by ServiceDetails and
+// ServiceMetricsStream, falling back to defaultHealthRateInterval when absent.
+func resolveRateInterval(r *http.Request) string {
+	if rateInterval := r.URL.Query().Get("rateInterval"); rateInterval != "" {
+		return rateInterval
+	}
+	return defaultHealthRateInterval
+}
+
 // ServiceDetails is the API handler to fetch full details of an specific service
 func ServiceDetails(w http.ResponseWriter, r *http.Request) {
 	// Get business layer
@@ -73,10 +343,7 @@ func ServiceDetails(w http.ResponseWriter, r *http.Request) {
 
 	// Rate interval is needed to fetch request rates based health
 	queryParams := r.URL.Query()
-	rateInterval := queryParams.Get("rateInterval")
-	if rateInterval == "" {
-		rateInterval = defaultHealthRateInterval
-	}
+	rateInterval := resolveRateInterval(r)
 
 	includeValidations := false
 	if _, found := queryParams["validate"]; found {
@@ -101,7 +368,7 @@ func ServiceDetails(w http.ResponseWriter, r *http.Request) {
 		wg.Add(1)
 		go func() {
 			defer wg.Done()
-			istioConfigValidations, errValidations = business.Validations.GetValidations(namespace, service)
+			istioConfigValidations, errValidations = fetchServiceValidations(business, namespace, service, "", "")
 		}()
 	}
 
@@ -120,17 +387,61 @@ func ServiceDetails(w http.ResponseWriter, r *http.Request) {
 	RespondWithJSON(w, http.StatusOK, serviceDetails)
 }
 
+// fetchServiceValidations resolves the Istio config validations for a service, optionally
+// narrowed down to a single object via objectType/objectName, so that it can be shared
+// between ServiceDetails (when ?validate is set) and the standalone ServiceValidations handler.
+func fetchServiceValidations(business *business.Layer, namespace, service, objectType, objectName string) (models.IstioValidations, error) {
+	return business.Validations.GetValidations(namespace, service, objectType, objectName)
+}
+
+// ServiceValidations is the API handler to fetch the Istio config validations for a single
+// service, without having to fetch and recompute the rest of the service details.
+func ServiceValidations(w http.ResponseWriter, r *http.Request) {
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	service := vars["service"]
+
+	queryParams := r.URL.Query()
+	objectType := queryParams.Get("objectType")
+	objectName := queryParams.Get("objectName")
+
+	istioConfigValidations, err := fetchServiceValidations(businessLayer, namespace, service, objectType, objectName)
+	if err != nil {
+		var unsupportedTypeErr *business.UnsupportedValidationObjectTypeError
+		if errors.As(err, &unsupportedTypeErr) {
+			RespondWithError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, istioConfigValidations)
+}
+
 // ServiceDashboard is the API handler to fetch Istio dashboard, related to a single service
 func ServiceDashboard(w http.ResponseWriter, r *http.Request) {
+	serviceDashboard(w, r, defaultMetricsClientSupplier)
+}
+
+// serviceDashboard (mock-friendly version)
+func serviceDashboard(w http.ResponseWriter, r *http.Request, metricsSupplier metricsClientSupplier) {
 	vars := mux.Vars(r)
 	namespace := vars["namespace"]
 	service := vars["service"]
 
-	prom, namespaceInfo := initClientsForMetrics(w, r, defaultPromClientSupplier, namespace)
+	prom, namespaceInfo := initClientsForMetrics(w, r, metricsSupplier, namespace)
 	if prom == nil {
 		// any returned value nil means error & response already written
 		return
 	}
+	forwardOriginalQuery(prom, r)
 
 	params := prometheus.IstioMetricsQuery{Namespace: namespace, Service: service}
 	err := extractIstioMetricsQueryParams(r, &params, namespaceInfo)
@@ -147,3 +458,274 @@ func ServiceDashboard(w http.ResponseWriter, r *http.Request) {
 	}
 	RespondWithJSON(w, http.StatusOK, dashboard)
 }
+
+// ServiceCustomDashboard is the API handler to fetch a single custom runtime
+// dashboard for the pods backing a given service. The dashboard name is checked
+// against the pods' annotations; the response carries the service's Istio metrics,
+// not charts read from a MonitoringDashboard CR.
+func ServiceCustomDashboard(w http.ResponseWriter, r *http.Request) {
+	serviceCustomDashboard(w, r, defaultMetricsClientSupplier)
+}
+
+// serviceCustomDashboard (mock-friendly version)
+func serviceCustomDashboard(w http.ResponseWriter, r *http.Request, metricsSupplier metricsClientSupplier) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	service := vars["service"]
+	dashboardName := vars["dashboard"]
+
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	prom, namespaceInfo := initClientsForMetrics(w, r, metricsSupplier, namespace)
+	if prom == nil {
+		// any returned value nil means error & response already written
+		return
+	}
+	forwardOriginalQuery(prom, r)
+
+	pods, err := businessLayer.Svc.GetServicePods(namespace, service)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	params := prometheus.IstioMetricsQuery{Namespace: namespace, Service: service}
+	if err := extractIstioMetricsQueryParams(r, &params, namespaceInfo); err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	svc := business.NewDashboardsService(prom)
+	dashboard, err := svc.GetCustomDashboard(namespace, dashboardName, pods, params)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+	RespondWithJSON(w, http.StatusOK, dashboard)
+}
+
+// ServiceCustomDashboards is the API handler to discover which custom runtime
+// dashboards apply to a service, resolved from the union of the pods backing
+// the service (as selected by its VirtualServices/DestinationRules).
+func ServiceCustomDashboards(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	service := vars["service"]
+
+	businessLayer, err := getBusiness(r)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	pods, err := businessLayer.Svc.GetServicePods(namespace, service)
+	if err != nil {
+		handleErrorResponse(w, err)
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, collectCustomDashboardRefs(namespace, pods.GroupByAppAndVersion()))
+}
+
+// getCustomDashboardRefsFunc resolves the custom dashboard refs for a single app/version
+// group of pods. It is a variable, rather than a direct call to business.GetCustomDashboardRefs,
+// so that tests can substitute a stub instead of reaching into the business layer.
+var getCustomDashboardRefsFunc = business.GetCustomDashboardRefs
+
+// collectCustomDashboardRefs merges the custom dashboard refs for every app/version group of
+// pods backing a service into a single, deduplication-free list.
+func collectCustomDashboardRefs(namespace string, podGroups []models.WorkloadPodsGroup) models.MonitoringDashboardsRefs {
+	refs := models.MonitoringDashboardsRefs{}
+	for _, podGroup := range podGroups {
+		refs = append(refs, getCustomDashboardRefsFunc(namespace, podGroup.App, podGroup.Version, podGroup.Pods)...)
+	}
+	return refs
+}
+
+// resolveRequestedNamespaces expands the "namespaces" query parameter into a concrete
+// list of namespace names, treating an empty value or "all" as every accessible namespace.
+func resolveRequestedNamespaces(businessLayer *business.Layer, raw string) ([]string, error) {
+	if raw != "" && raw != "all" {
+		namespaces := make([]string, 0)
+		for _, ns := range strings.Split(raw, ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				namespaces = append(namespaces, ns)
+			}
+		}
+		return namespaces, nil
+	}
+
+	accessibleNamespaces, err := businessLayer.Namespace.GetNamespaces()
+	if err != nil {
+		return nil, err
+	}
+
+	namespaces := make([]string, len(accessibleNamespaces))
+	for i, ns := range accessibleNamespaces {
+		namespaces[i] = ns.Name
+	}
+	return namespaces, nil
+}
+
+// fetchServiceOverviews fans out business.Svc.GetServiceList across namespaces using a
+// bounded worker pool and merges the resulting services into a single slice.
+func fetchServiceOverviews(businessLayer *business.Layer, namespaces []string, labelSelector string) ([]models.ServiceOverview, error) {
+	// Parsed once up front: every namespace filters against the same selector, and a
+	// malformed one should fail the whole request instead of being silently ignored
+	// per-namespace.
+	var selector labels.Selector
+	if labelSelector != "" {
+		parsed, err := labels.Parse(labelSelector)
+		if err != nil {
+			return nil, &invalidLabelSelectorError{labelSelector: labelSelector, err: err}
+		}
+		selector = parsed
+	}
+
+	type namespaceResult struct {
+		services []models.ServiceOverview
+		err      error
+	}
+
+	results := make([]namespaceResult, len(namespaces))
+	sem := make(chan struct{}, maxConcurrentNamespaceFetches)
+	wg := sync.WaitGroup{}
+
+	for i, namespace := range namespaces {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, namespace string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			serviceList, err := businessLayer.Svc.GetServiceList(namespace)
+			if err != nil {
+				results[i] = namespaceResult{err: err}
+				return
+			}
+
+			services := serviceList.Services
+			if selector != nil {
+				services = filterServicesBySelector(services, selector)
+			}
+			results[i] = namespaceResult{services: services}
+		}(i, namespace)
+	}
+	wg.Wait()
+
+	merged := make([]models.ServiceOverview, 0)
+	for _, result := range results {
+		if result.err != nil {
+			return nil, result.err
+		}
+		merged = append(merged, result.services...)
+	}
+	return merged, nil
+}
+
+// invalidLabelSelectorError reports a labelSelector query parameter that failed to parse as
+// a Kubernetes label selector expression. ServiceListMulti responds to it with a 400 rather
+// than treating it as a generic (500-worthy) fetch failure.
+type invalidLabelSelectorError struct {
+	labelSelector string
+	err           error
+}
+
+func (e *invalidLabelSelectorError) Error() string {
+	return fmt.Sprintf("invalid labelSelector %q: %s", e.labelSelector, e.err.Error())
+}
+
+func (e *invalidLabelSelectorError) Unwrap() error {
+	return e.err
+}
+
+// filterServicesBySelector keeps only the services whose labels match selector.
+func filterServicesBySelector(services []models.ServiceOverview, selector labels.Selector) []models.ServiceOverview {
+	filtered := make([]models.ServiceOverview, 0, len(services))
+	for _, svc := range services {
+		if selector.Matches(labels.Set(svc.Labels)) {
+			filtered = append(filtered, svc)
+		}
+	}
+	return filtered
+}
+
+// filterServicesByName keeps only the services whose name contains the given substring.
+func filterServicesByName(services []models.ServiceOverview, nameContains string) []models.ServiceOverview {
+	filtered := make([]models.ServiceOverview, 0, len(services))
+	for _, svc := range services {
+		if strings.Contains(svc.Name, nameContains) {
+			filtered = append(filtered, svc)
+		}
+	}
+	return filtered
+}
+
+// filterServicesByHealth keeps only the services whose computed health status matches
+// the requested one ("healthy", "degraded" or "failure").
+func filterServicesByHealth(services []models.ServiceOverview, health string) []models.ServiceOverview {
+	filtered := make([]models.ServiceOverview, 0, len(services))
+	for _, svc := range services {
+		if svc.Health.GetGlobalStatus().Name == health {
+			filtered = append(filtered, svc)
+		}
+	}
+	return filtered
+}
+
+// sortServiceOverviews sorts services in place by name, health status or error rate.
+// Unknown or empty sortBy values default to sorting by name.
+func sortServiceOverviews(services []models.ServiceOverview, sortBy string) {
+	switch sortBy {
+	case "health":
+		sort.SliceStable(services, func(i, j int) bool {
+			return services[i].Health.GetGlobalStatus().Name < services[j].Health.GetGlobalStatus().Name
+		})
+	case "errorRate":
+		sort.SliceStable(services, func(i, j int) bool {
+			return services[i].Health.Requests.ErrorRatio() > services[j].Health.Requests.ErrorRatio()
+		})
+	default:
+		sort.SliceStable(services, func(i, j int) bool {
+			return services[i].Name < services[j].Name
+		})
+	}
+}
+
+// parseServiceListPagination reads the "page" and "pageSize" query parameters, falling
+// back to page 1 and defaultServiceListPageSize when missing or invalid.
+func parseServiceListPagination(queryParams url.Values) (page int, pageSize int) {
+	page = 1
+	if raw := queryParams.Get("page"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	pageSize = defaultServiceListPageSize
+	if raw := queryParams.Get("pageSize"); raw != "" {
+		if parsed, err := strconv.Atoi(raw); err == nil && parsed > 0 {
+			pageSize = parsed
+		}
+	}
+	return page, pageSize
+}
+
+// paginateServiceOverviews slices the given, already-sorted services down to the
+// requested page. An out-of-range page returns an empty slice rather than an error.
+func paginateServiceOverviews(services []models.ServiceOverview, page int, pageSize int) []models.ServiceOverview {
+	start := (page - 1) * pageSize
+	if start < 0 || start >= len(services) {
+		return []models.ServiceOverview{}
+	}
+
+	end := start + pageSize
+	if end > len(services) {
+		end = len(services)
+	}
+	return services[start:end]
+}
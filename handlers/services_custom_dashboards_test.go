@@ -0,0 +1,36 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/models"
+)
+
+func TestCollectCustomDashboardRefsMergesEveryGroup(t *testing.T) {
+	original := getCustomDashboardRefsFunc
+	defer func() { getCustomDashboardRefsFunc = original }()
+
+	var seenGroups [][2]string
+	getCustomDashboardRefsFunc = func(namespace, app, version string, pods []string) models.MonitoringDashboardsRefs {
+		seenGroups = append(seenGroups, [2]string{app, version})
+		return make(models.MonitoringDashboardsRefs, 1)
+	}
+
+	groups := []models.WorkloadPodsGroup{
+		{App: "reviews", Version: "v1", Pods: []string{"reviews-v1-abc"}},
+		{App: "reviews", Version: "v2", Pods: []string{"reviews-v2-xyz"}},
+	}
+
+	refs := collectCustomDashboardRefs("bookinfo", groups)
+
+	assert.Len(t, refs, 2)
+	assert.Equal(t, [][2]string{{"reviews", "v1"}, {"reviews", "v2"}}, seenGroups)
+}
+
+func TestCollectCustomDashboardRefsNoGroups(t *testing.T) {
+	refs := collectCustomDashboardRefs("bookinfo", nil)
+
+	assert.Len(t, refs, 0)
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/models"
+)
+
+func overviewsNamed(names ...string) []models.ServiceOverview {
+	overviews := make([]models.ServiceOverview, len(names))
+	for i, name := range names {
+		overviews[i] = models.ServiceOverview{Name: name}
+	}
+	return overviews
+}
+
+func TestFilterServicesByNameKeepsOnlyMatches(t *testing.T) {
+	overviews := overviewsNamed("reviews", "ratings", "productpage")
+
+	filtered := filterServicesByName(overviews, "rating")
+
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "ratings", filtered[0].Name)
+}
+
+func TestFilterServicesBySelectorKeepsOnlyMatches(t *testing.T) {
+	overviews := []models.ServiceOverview{
+		{Name: "reviews", Labels: map[string]string{"app": "reviews", "version": "v1"}},
+		{Name: "reviews-v2", Labels: map[string]string{"app": "reviews", "version": "v2"}},
+	}
+	selector, err := labels.Parse("version=v1")
+	assert.NoError(t, err)
+
+	filtered := filterServicesBySelector(overviews, selector)
+
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "reviews", filtered[0].Name)
+}
+
+func TestInvalidLabelSelectorErrorWrapsTheParseError(t *testing.T) {
+	parseErr := errors.New("couldn't parse the selector")
+	err := &invalidLabelSelectorError{labelSelector: "???", err: parseErr}
+
+	assert.ErrorIs(t, err, parseErr)
+	assert.Contains(t, err.Error(), "???")
+}
+
+func TestSortServiceOverviewsDefaultsToName(t *testing.T) {
+	overviews := overviewsNamed("reviews", "ratings", "productpage")
+
+	sortServiceOverviews(overviews, "")
+
+	names := []string{overviews[0].Name, overviews[1].Name, overviews[2].Name}
+	assert.Equal(t, []string{"productpage", "ratings", "reviews"}, names)
+}
+
+func TestParseServiceListPaginationDefaults(t *testing.T) {
+	page, pageSize := parseServiceListPagination(url.Values{})
+
+	assert.Equal(t, 1, page)
+	assert.Equal(t, defaultServiceListPageSize, pageSize)
+}
+
+func TestParseServiceListPaginationReadsQueryParams(t *testing.T) {
+	page, pageSize := parseServiceListPagination(url.Values{"page": {"3"}, "pageSize": {"5"}})
+
+	assert.Equal(t, 3, page)
+	assert.Equal(t, 5, pageSize)
+}
+
+func TestParseServiceListPaginationIgnoresInvalidValues(t *testing.T) {
+	page, pageSize := parseServiceListPagination(url.Values{"page": {"not-a-number"}, "pageSize": {"-1"}})
+
+	assert.Equal(t, 1, page)
+	assert.Equal(t, defaultServiceListPageSize, pageSize)
+}
+
+func TestPaginateServiceOverviewsMiddlePage(t *testing.T) {
+	overviews := overviewsNamed("a", "b", "c", "d", "e")
+
+	page := paginateServiceOverviews(overviews, 2, 2)
+
+	assert.Len(t, page, 2)
+	assert.Equal(t, "c", page[0].Name)
+	assert.Equal(t, "d", page[1].Name)
+}
+
+func TestPaginateServiceOverviewsOutOfRangeReturnsEmpty(t *testing.T) {
+	overviews := overviewsNamed("a", "b")
+
+	page := paginateServiceOverviews(overviews, 5, 2)
+
+	assert.Len(t, page, 0)
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/kiali/kiali/prometheus"
+)
+
+func TestResolveRateIntervalFallsBackToDefault(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/namespaces/bookinfo/services/reviews/dashboard", nil)
+
+	assert.Equal(t, defaultHealthRateInterval, resolveRateInterval(r))
+}
+
+func TestResolveRateIntervalHonorsQueryParam(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/namespaces/bookinfo/services/reviews/dashboard?rateInterval=5m", nil)
+
+	assert.Equal(t, "5m", resolveRateInterval(r))
+}
+
+func TestResolveStreamResumeStartWithoutHeaderIsNotOK(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/namespaces/bookinfo/services/reviews/metrics/stream", nil)
+
+	_, ok := resolveStreamResumeStart(r)
+
+	assert.False(t, ok)
+}
+
+func TestResolveStreamResumeStartWithInvalidHeaderIsNotOK(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/namespaces/bookinfo/services/reviews/metrics/stream", nil)
+	r.Header.Set("Last-Event-ID", "not-a-timestamp")
+
+	_, ok := resolveStreamResumeStart(r)
+
+	assert.False(t, ok)
+}
+
+func TestResolveStreamResumeStartParsesUnixTimestamp(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/namespaces/bookinfo/services/reviews/metrics/stream", nil)
+	r.Header.Set("Last-Event-ID", "1700000000")
+
+	resumeFrom, ok := resolveStreamResumeStart(r)
+
+	assert.True(t, ok)
+	assert.True(t, resumeFrom.Equal(time.Unix(1700000000, 0)))
+}
+
+// fakeMetricsProvider is a minimal prometheus.MetricsProvider used to drive streamMetricsLoop
+// in tests without a real backend.
+type fakeMetricsProvider struct{}
+
+func (fakeMetricsProvider) GetMetrics(query *prometheus.IstioMetricsQuery) prometheus.Metrics {
+	return prometheus.Metrics{}
+}
+
+func TestStreamMetricsLoopEmitsASnapshotPerTick(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+
+	var mu sync.Mutex
+	healthCalls := 0
+	fetchHealth := func(rateInterval string, queryTime time.Time) (interface{}, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		healthCalls++
+		return "healthy", nil
+	}
+
+	done := make(chan struct{})
+	go func() {
+		streamMetricsLoop(ctx, w, w, fakeMetricsProvider{}, prometheus.IstioMetricsQuery{Namespace: "bookinfo", Service: "reviews"}, "1m", 5*time.Millisecond, fetchHealth)
+		close(done)
+	}()
+
+	time.Sleep(30 * time.Millisecond)
+	cancel()
+	<-done
+
+	mu.Lock()
+	calls := healthCalls
+	mu.Unlock()
+
+	assert.GreaterOrEqual(t, calls, 2, "expected streamMetricsLoop to tick more than once in 30ms with a 5ms interval")
+
+	body := w.Body.String()
+	assert.Contains(t, body, "id: ")
+	assert.Contains(t, body, `data: {"metrics"`)
+	assert.Equal(t, calls, strings.Count(body, "id: "))
+}
+
+func TestStreamMetricsLoopStopsWhenContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	w := httptest.NewRecorder()
+	cancel()
+
+	done := make(chan struct{})
+	go func() {
+		streamMetricsLoop(ctx, w, w, fakeMetricsProvider{}, prometheus.IstioMetricsQuery{}, "1m", time.Second, func(string, time.Time) (interface{}, error) {
+			return "healthy", nil
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("streamMetricsLoop did not return promptly after its context was canceled")
+	}
+}
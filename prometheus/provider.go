@@ -0,0 +1,219 @@
+package prometheus
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kiali/kiali/log"
+)
+
+// remoteMetricsClientTimeout bounds how long RemoteMetricsClient waits for the configured
+// upstream to answer, so a wedged remote backend cannot hang every metrics request forever.
+const remoteMetricsClientTimeout = 30 * time.Second
+
+// ThanosConfig holds the settings an operator sets under
+// externalservices.prometheus.thanos. Config embeds it as its Thanos field, alongside the
+// plain Prometheus connection settings it already carries.
+type ThanosConfig struct {
+	// StoreMatchers is passed through as repeated store_matchers[] query parameters on
+	// every request, letting the operator scope a query down to a subset of the Thanos
+	// store API servers behind the queried endpoint.
+	StoreMatchers []string `yaml:"store_matchers,omitempty"`
+	// MaxSourceResolution is passed through as max_source_resolution on every request.
+	MaxSourceResolution string `yaml:"max_source_resolution,omitempty"`
+}
+
+// MetricsProvider is implemented by anything that can answer an IstioMetricsQuery with
+// Metrics, regardless of which time-series backend actually stores the samples. Handlers
+// depend only on this interface so that the concrete backend can be swapped via the
+// metrics_backend server config without touching handler code.
+//
+// *Client already satisfies this interface via its existing GetMetrics method.
+type MetricsProvider interface {
+	GetMetrics(query *IstioMetricsQuery) Metrics
+}
+
+// NewMetricsProvider builds the MetricsProvider selected by the "metrics_backend" server
+// config ("prometheus", "thanos" or "remote"), defaulting to plain Prometheus when unset.
+// The Thanos branch reads its store_matchers[]/max_source_resolution from cfg.Thanos, so an
+// operator who sets those under externalservices.prometheus.thanos actually has them applied
+// instead of every Thanos query going out with the defaults.
+func NewMetricsProvider(backend string, cfg Config, remoteURL string) (MetricsProvider, error) {
+	switch backend {
+	case "", "prometheus":
+		return NewClient(cfg)
+	case "thanos":
+		return NewThanosClient(cfg, cfg.Thanos.StoreMatchers, cfg.Thanos.MaxSourceResolution)
+	case "remote":
+		return NewRemoteMetricsClient(remoteURL)
+	default:
+		return nil, fmt.Errorf("unknown metrics_backend %q", backend)
+	}
+}
+
+// thanosParamsRoundTripper injects the query parameters the Thanos Query API expects
+// (store_matchers[], max_source_resolution, dedup, partial_response) into every outgoing
+// request, so ThanosClient can reuse Client's existing query-building and HTTP plumbing
+// unchanged instead of duplicating it.
+type thanosParamsRoundTripper struct {
+	next                http.RoundTripper
+	storeMatchers       []string
+	maxSourceResolution string
+}
+
+func (rt *thanosParamsRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	query := req.URL.Query()
+	query.Set("dedup", "true")
+	query.Set("partial_response", strconv.FormatBool(true))
+	if rt.maxSourceResolution != "" {
+		query.Set("max_source_resolution", rt.maxSourceResolution)
+	}
+	for _, matcher := range rt.storeMatchers {
+		query.Add("store_matchers[]", matcher)
+	}
+	req.URL.RawQuery = query.Encode()
+
+	transport := rt.next
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(req)
+}
+
+// ThanosClient queries a Thanos Query API endpoint rather than a single Prometheus server.
+// It embeds Client and so inherits GetMetrics unchanged; the Thanos-specific query
+// parameters are added transparently by the RoundTripper installed in NewThanosClient.
+type ThanosClient struct {
+	*Client
+}
+
+// NewThanosClient builds a ThanosClient that passes through storeMatchers and
+// maxSourceResolution on every query, and always deduplicates replicated samples.
+func NewThanosClient(cfg Config, storeMatchers []string, maxSourceResolution string) (*ThanosClient, error) {
+	cfg.RoundTripper = &thanosParamsRoundTripper{
+		next:                cfg.RoundTripper,
+		storeMatchers:       storeMatchers,
+		maxSourceResolution: maxSourceResolution,
+	}
+
+	client, err := NewClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ThanosClient{Client: client}, nil
+}
+
+// RemoteMetricsClient forwards a metrics request as-is to an external, already-running
+// Kiali-compatible metrics gateway instead of querying a time-series backend directly, so
+// operators can point Kiali at a shared metrics proxy without recompiling.
+type RemoteMetricsClient struct {
+	baseURL        *url.URL
+	http           *http.Client
+	forwardedQuery string
+}
+
+// NewRemoteMetricsClient builds a RemoteMetricsClient that proxies requests to baseURL.
+func NewRemoteMetricsClient(baseURL string) (*RemoteMetricsClient, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid remote metrics backend URL: %s", err.Error())
+	}
+	return &RemoteMetricsClient{baseURL: parsed, http: &http.Client{Timeout: remoteMetricsClientTimeout}}, nil
+}
+
+// SetForwardedQuery records the raw query string of the client's original HTTP request, so
+// GetMetrics forwards every filter it asked for (step, rateFunc, direction, reporter,
+// quantiles, byLabels, ...) to the upstream gateway, not just the time window captured by
+// IstioMetricsQuery. Callers with no original request to forward (e.g. the gRPC surface)
+// can leave this unset; GetMetrics then falls back to rebuilding the query string from
+// query's own fields.
+func (in *RemoteMetricsClient) SetForwardedQuery(rawQuery string) {
+	in.forwardedQuery = rawQuery
+}
+
+// GetMetrics forwards the query to the configured upstream's
+// /api/namespaces/{namespace}/services|apps|workloads/{name}/metrics endpoint (mirroring
+// whichever of query.Service/App/Workload is set, the same way the local handlers pick their
+// REST route) and returns the upstream's response body decoded as Metrics; the body is read
+// in full before it is decoded, not streamed through. Any error talking to the upstream, including an
+// IstioMetricsQuery that names none of Service/App/Workload, is logged and results in an
+// empty Metrics value, matching how the rest of this package degrades on a failed scrape.
+func (in *RemoteMetricsClient) GetMetrics(query *IstioMetricsQuery) Metrics {
+	path, err := remoteMetricsPath(query)
+	if err != nil {
+		log.Errorf("RemoteMetricsClient: %s", err.Error())
+		return Metrics{}
+	}
+	upstream := *in.baseURL
+	upstream.Path = strings.TrimRight(upstream.Path, "/") + path
+	upstream.RawQuery = remoteMetricsQueryString(query, in.forwardedQuery)
+
+	resp, err := in.http.Get(upstream.String())
+	if err != nil {
+		log.Errorf("RemoteMetricsClient: request to %s failed: %s", upstream.String(), err.Error())
+		return Metrics{}
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		log.Errorf("RemoteMetricsClient: reading response from %s failed: %s", upstream.String(), err.Error())
+		return Metrics{}
+	}
+	if resp.StatusCode != http.StatusOK {
+		log.Errorf("RemoteMetricsClient: %s returned status %d: %s", upstream.String(), resp.StatusCode, string(body))
+		return Metrics{}
+	}
+
+	var metrics Metrics
+	if err := metrics.UnmarshalJSON(body); err != nil {
+		log.Errorf("RemoteMetricsClient: decoding response from %s failed: %s", upstream.String(), err.Error())
+		return Metrics{}
+	}
+	return metrics
+}
+
+// remoteMetricsPath builds the upstream path for query, branching on whichever of
+// Service/App/Workload it names, the same way the local REST routes do
+// (/services/{service}, /apps/{app}, /workloads/{workload}). Exactly one of them is expected
+// to be set; if none is, there is no well-formed path to build and the query is rejected
+// rather than silently hitting a malformed, always-empty-name path.
+func remoteMetricsPath(query *IstioMetricsQuery) (string, error) {
+	switch {
+	case query.Service != "":
+		return fmt.Sprintf("/api/namespaces/%s/services/%s/metrics", query.Namespace, query.Service), nil
+	case query.App != "":
+		return fmt.Sprintf("/api/namespaces/%s/apps/%s/metrics", query.Namespace, query.App), nil
+	case query.Workload != "":
+		return fmt.Sprintf("/api/namespaces/%s/workloads/%s/metrics", query.Namespace, query.Workload), nil
+	default:
+		return "", fmt.Errorf("query for namespace %q names none of Service, App or Workload", query.Namespace)
+	}
+}
+
+// remoteMetricsQueryString builds the query string forwarded to the remote gateway. When
+// forwardedQuery is non-empty (the client's original HTTP request query, set via
+// RemoteMetricsClient.SetForwardedQuery), every one of its parameters is forwarded as-is, so
+// a remote backend sees the same step/rateFunc/direction/reporter/quantiles/byLabels/... the
+// client originally asked for. start/end are then overlaid from query's own time window,
+// since that can move between calls (e.g. each tick of a metrics SSE stream) even while the
+// rest of the original request stays constant. When forwardedQuery is empty, the query
+// string is rebuilt from query's time window alone.
+func remoteMetricsQueryString(query *IstioMetricsQuery, forwardedQuery string) string {
+	values, _ := url.ParseQuery(forwardedQuery)
+	if values == nil {
+		values = url.Values{}
+	}
+	if !query.Start.IsZero() {
+		values.Set("start", strconv.FormatInt(query.Start.Unix(), 10))
+	}
+	if !query.End.IsZero() {
+		values.Set("end", strconv.FormatInt(query.End.Unix(), 10))
+	}
+	return values.Encode()
+}
@@ -0,0 +1,169 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewMetricsProviderRejectsUnknownBackend(t *testing.T) {
+	_, err := NewMetricsProvider("bogus", Config{}, "")
+
+	assert.Error(t, err)
+}
+
+func TestNewMetricsProviderThanosBackendReturnsThanosClient(t *testing.T) {
+	cfg := Config{Thanos: ThanosConfig{StoreMatchers: []string{`cluster="prod"`}, MaxSourceResolution: "5m"}}
+
+	provider, err := NewMetricsProvider("thanos", cfg, "")
+
+	assert.NoError(t, err)
+	_, ok := provider.(*ThanosClient)
+	assert.True(t, ok, "expected the thanos backend to produce a *ThanosClient")
+}
+
+func TestThanosParamsRoundTripperInjectsExpectedParams(t *testing.T) {
+	var capturedQuery string
+	base := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		capturedQuery = req.URL.RawQuery
+		return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+	})
+
+	rt := &thanosParamsRoundTripper{
+		next:                base,
+		storeMatchers:       []string{`cluster="prod"`},
+		maxSourceResolution: "5m",
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "http://thanos.example/api/v1/query_range", nil)
+	_, err := rt.RoundTrip(req)
+
+	assert.NoError(t, err)
+	query := req.URL.Query()
+	assert.Equal(t, "true", query.Get("dedup"))
+	assert.Equal(t, "true", query.Get("partial_response"))
+	assert.Equal(t, "5m", query.Get("max_source_resolution"))
+	assert.Equal(t, []string{`cluster="prod"`}, query["store_matchers[]"])
+	assert.NotEmpty(t, capturedQuery)
+}
+
+func TestRemoteMetricsQueryStringIncludesTimeWindow(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	query := &IstioMetricsQuery{Namespace: "bookinfo", Service: "reviews", Start: start, End: end}
+
+	raw := remoteMetricsQueryString(query, "")
+
+	values, err := url.ParseQuery(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "1000", values.Get("start"))
+	assert.Equal(t, "2000", values.Get("end"))
+}
+
+func TestRemoteMetricsQueryStringForwardsOriginalRequestParams(t *testing.T) {
+	start := time.Unix(1000, 0)
+	end := time.Unix(2000, 0)
+	query := &IstioMetricsQuery{Namespace: "bookinfo", Service: "reviews", Start: start, End: end}
+
+	raw := remoteMetricsQueryString(query, "step=30s&rateFunc=rate&byLabels[]=response_code")
+
+	values, err := url.ParseQuery(raw)
+	assert.NoError(t, err)
+	assert.Equal(t, "30s", values.Get("step"))
+	assert.Equal(t, "rate", values.Get("rateFunc"))
+	assert.Equal(t, []string{"response_code"}, values["byLabels[]"])
+	assert.Equal(t, "1000", values.Get("start"))
+	assert.Equal(t, "2000", values.Get("end"))
+}
+
+func TestRemoteMetricsClientSetForwardedQueryIsUsedByGetMetrics(t *testing.T) {
+	var capturedQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedQuery = r.URL.RawQuery
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewRemoteMetricsClient(server.URL)
+	assert.NoError(t, err)
+	client.SetForwardedQuery("step=30s")
+
+	client.GetMetrics(&IstioMetricsQuery{Namespace: "bookinfo", Service: "reviews"})
+
+	values, err := url.ParseQuery(capturedQuery)
+	assert.NoError(t, err)
+	assert.Equal(t, "30s", values.Get("step"))
+}
+
+func TestRemoteMetricsPathBranchesOnQueryShape(t *testing.T) {
+	servicePath, err := remoteMetricsPath(&IstioMetricsQuery{Namespace: "bookinfo", Service: "reviews"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/namespaces/bookinfo/services/reviews/metrics", servicePath)
+
+	appPath, err := remoteMetricsPath(&IstioMetricsQuery{Namespace: "bookinfo", App: "reviews"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/namespaces/bookinfo/apps/reviews/metrics", appPath)
+
+	workloadPath, err := remoteMetricsPath(&IstioMetricsQuery{Namespace: "bookinfo", Workload: "reviews-v1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "/api/namespaces/bookinfo/workloads/reviews-v1/metrics", workloadPath)
+
+	_, err = remoteMetricsPath(&IstioMetricsQuery{Namespace: "bookinfo"})
+	assert.Error(t, err)
+}
+
+func TestRemoteMetricsClientGetMetricsRoutesAppQuery(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewRemoteMetricsClient(server.URL)
+	assert.NoError(t, err)
+
+	client.GetMetrics(&IstioMetricsQuery{Namespace: "bookinfo", App: "reviews"})
+
+	assert.Equal(t, "/api/namespaces/bookinfo/apps/reviews/metrics", requestedPath)
+}
+
+func TestRemoteMetricsClientGetMetricsRoutesWorkloadQuery(t *testing.T) {
+	var requestedPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client, err := NewRemoteMetricsClient(server.URL)
+	assert.NoError(t, err)
+
+	client.GetMetrics(&IstioMetricsQuery{Namespace: "bookinfo", Workload: "reviews-v1"})
+
+	assert.Equal(t, "/api/namespaces/bookinfo/workloads/reviews-v1/metrics", requestedPath)
+}
+
+func TestRemoteMetricsClientGetMetricsOnUpstreamErrorReturnsEmpty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client, err := NewRemoteMetricsClient(server.URL)
+	assert.NoError(t, err)
+
+	metrics := client.GetMetrics(&IstioMetricsQuery{Namespace: "bookinfo", Service: "reviews"})
+
+	assert.Equal(t, Metrics{}, metrics)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}